@@ -0,0 +1,46 @@
+package golaunch
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+)
+
+// AdapterFactory opens a Launch for the backend identified by the scheme it
+// was registered under. u is the full spec passed to Open, so a factory can
+// read u.Host, u.User and any other part it needs.
+type AdapterFactory func(ctx context.Context, u *url.URL) (Launch, error)
+
+var (
+	adaptersMu sync.Mutex
+	adapters   = make(map[string]AdapterFactory)
+)
+
+// RegisterAdapter makes a backend available to Open under the given URI
+// scheme (e.g. "ble", "buttplug", "mock"). It is meant to be called from an
+// init function. Registering the same name twice overwrites the previous
+// factory.
+func RegisterAdapter(name string, factory AdapterFactory) {
+	adaptersMu.Lock()
+	defer adaptersMu.Unlock()
+	adapters[name] = factory
+}
+
+// Open parses spec as a URI (e.g. "ble://", "buttplug://user@host:12345",
+// "mock://") and returns a connected Launch from the adapter registered for
+// its scheme.
+func Open(ctx context.Context, spec string) (Launch, error) {
+	u, err := url.Parse(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	adaptersMu.Lock()
+	factory, ok := adapters[u.Scheme]
+	adaptersMu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("golaunch: no adapter registered for scheme %q", u.Scheme)
+	}
+	return factory(ctx, u)
+}
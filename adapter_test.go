@@ -0,0 +1,47 @@
+package golaunch
+
+import (
+	"context"
+	"net/url"
+	"testing"
+)
+
+func TestOpenUnknownScheme(t *testing.T) {
+	if _, err := Open(context.Background(), "nosuch://"); err == nil {
+		t.Fatal("Open with an unregistered scheme: got nil error, want non-nil")
+	}
+}
+
+func TestOpenMock(t *testing.T) {
+	l, err := Open(context.Background(), "mock://")
+	if err != nil {
+		t.Fatalf("Open(mock://): %v", err)
+	}
+	if _, ok := l.(*MockLaunch); !ok {
+		t.Fatalf("Open(mock://) = %T, want *MockLaunch", l)
+	}
+}
+
+func TestRegisterAdapterOverwrites(t *testing.T) {
+	firstCalled := false
+	RegisterAdapter("adaptertest", func(ctx context.Context, u *url.URL) (Launch, error) {
+		firstCalled = true
+		return NewMockLaunch(), nil
+	})
+	called := false
+	RegisterAdapter("adaptertest", func(ctx context.Context, u *url.URL) (Launch, error) {
+		called = true
+		return NewMockLaunch(), nil
+	})
+	defer delete(adapters, "adaptertest")
+
+	if _, err := Open(context.Background(), "adaptertest://"); err != nil {
+		t.Fatalf("Open(adaptertest://): %v", err)
+	}
+	if !called {
+		t.Fatal("Open did not use the registered factory")
+	}
+	if firstCalled {
+		t.Fatal("Open used the factory that was overwritten")
+	}
+}
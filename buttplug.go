@@ -3,14 +3,60 @@ package golaunch
 import (
 	"context"
 	"crypto/tls"
+	"net/url"
+	"sync"
 	"time"
 
 	"github.com/funjack/golibbuttplug"
 )
 
-const buttplugLaunchName = "Fleshlight Launch"
+func init() {
+	RegisterAdapter("buttplug", openButtplug)
+	RegisterAdapter("buttplugs", openButtplugTLS)
+}
+
+// openButtplug is the "buttplug" AdapterFactory. It expects a spec like
+// buttplug://name@host:port, where name identifies us to the Buttplug
+// server; it defaults to "golaunch" if omitted. Use the "buttplugs" scheme
+// instead for a TLS connection.
+func openButtplug(ctx context.Context, u *url.URL) (Launch, error) {
+	return dialButtplug(ctx, u, nil)
+}
+
+// openButtplugTLS is the "buttplugs" AdapterFactory, identical to
+// openButtplug but connecting over TLS.
+func openButtplugTLS(ctx context.Context, u *url.URL) (Launch, error) {
+	return dialButtplug(ctx, u, &tls.Config{})
+}
+
+// dialButtplug builds the websocket address golibbuttplug.NewClient expects
+// from u.Host and connects. golibbuttplug.NewClient parses addr with
+// url.ParseRequestURI, which requires a scheme, so the bare host:port from
+// u.Host can't be passed through as-is.
+func dialButtplug(ctx context.Context, u *url.URL, tlscfg *tls.Config) (Launch, error) {
+	clientName := u.User.Username()
+	if clientName == "" {
+		clientName = "golaunch"
+	}
+	scheme := "ws"
+	if tlscfg != nil {
+		scheme = "wss"
+	}
+	l := NewButtplugLaunch(ctx, scheme+"://"+u.Host, clientName, tlscfg)
+	if err := l.Connect(ctx); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
 
 // buttplugLauch is a Launch connected via Buttplug.
+//
+// It only ever sends FleshlightLaunchFW12Cmd. Generic LinearCmd support and
+// protocol v3 negotiation, which chunk0-4 originally asked for, are deferred:
+// the vendored github.com/funjack/golibbuttplug has no LinearCmd method, no
+// CommandLinearCmd message type, and no version negotiation API to build
+// either on top of. Revisit once golibbuttplug is upgraded or forked to add
+// them.
 type buttplugLaunch struct {
 	ctx    context.Context
 	addr   string
@@ -20,27 +66,46 @@ type buttplugLaunch struct {
 	client *golibbuttplug.Client
 	device *golibbuttplug.Device
 
+	// transport, if set, replaces the real Buttplug connection.
+	transport Transport
+
+	transportMu  sync.Mutex
+	transportErr error
+
 	disconnect chan bool
-	wbuffer    chan [2]int
+	queue      *CommandQueue
 	limiter    <-chan time.Time
 
+	touchEvents chan TouchEvent
+
 	disconnectFunc func()
+	touchFunc      func(TouchEvent)
 }
 
 // NewButtplugLaunch creates a new Launch connected via the Buttplug server
 // running at addr. Identify with the Buttplug server with the given name.
 func NewButtplugLaunch(ctx context.Context, addr, name string, tlscfg *tls.Config) Launch {
 	return &buttplugLaunch{
-		ctx:        ctx,
-		addr:       addr,
-		tlscfg:     tlscfg,
-		name:       name,
-		disconnect: make(chan bool),
-		wbuffer:    make(chan [2]int, writeBufferSize),
-		limiter:    time.Tick(threshold),
+		ctx:         ctx,
+		addr:        addr,
+		tlscfg:      tlscfg,
+		name:        name,
+		disconnect:  make(chan bool),
+		queue:       NewCommandQueue(Block),
+		limiter:     time.Tick(threshold),
+		touchEvents: make(chan TouchEvent, touchEventBufferSize),
 	}
 }
 
+// NewButtplugLaunchWithTransport creates a Launch that sends every Move
+// over the given Transport instead of a real Buttplug connection. It is
+// meant for deterministic testing.
+func NewButtplugLaunchWithTransport(t Transport) Launch {
+	l, _ := NewButtplugLaunch(context.Background(), "", "", nil).(*buttplugLaunch)
+	l.transport = t
+	return l
+}
+
 // connect to Buttplug.
 func (l *buttplugLaunch) connect() error {
 	if l.client != nil {
@@ -62,6 +127,20 @@ func (l *buttplugLaunch) connect() error {
 // Connect sets up a connection with Buttplug and creates a connection with
 // a Launch.
 func (l *buttplugLaunch) Connect(ctx context.Context) error {
+	// A Transport replaces the real Buttplug connection entirely.
+	if l.transport != nil {
+		stopWriting := make(chan bool, 1)
+		go func() {
+			<-l.disconnect
+			stopWriting <- true
+			if l.disconnectFunc != nil {
+				l.disconnectFunc()
+			}
+		}()
+		go l.writeFromBuffer(stopWriting)
+		return nil
+	}
+
 	// Connect to Buttplug
 	if err := l.connect(); err != nil {
 		return err
@@ -89,9 +168,12 @@ func (l *buttplugLaunch) Connect(ctx context.Context) error {
 	} else if err != nil {
 		return err
 	}
-	// Get all known devices.
+	// Get all known devices. Match on supported messages rather than the
+	// literal "Fleshlight Launch" name, so forks like The Handy that
+	// advertise FleshlightLaunchFW12Cmd under a different name are picked
+	// up too.
 	for _, d := range l.client.Devices() {
-		if d.Name() == buttplugLaunchName && d.IsSupported(golibbuttplug.CommandFleshlightLaunchFW12) {
+		if d.IsSupported(golibbuttplug.CommandFleshlightLaunchFW12) {
 			l.device = d
 			break
 		}
@@ -100,7 +182,7 @@ func (l *buttplugLaunch) Connect(ctx context.Context) error {
 		return ErrDiscover
 	}
 	// Handle disconnects
-	stopWriting := make(chan bool)
+	stopWriting := make(chan bool, 1)
 	go func() {
 		select {
 		case <-l.device.Disconnected():
@@ -129,24 +211,47 @@ func (l *buttplugLaunch) Disconnect() {
 	}
 }
 
-// writeFromBuffer sends commands to the Launch that are stored in the write
-// buffer. To stop this function send true on the l.stopWriting channel.
+// writeFromBuffer sends commands to the Launch that are stored in the
+// command queue. To stop this function send true on the l.stopWriting
+// channel.
 func (l *buttplugLaunch) writeFromBuffer(stopchan <-chan bool) {
 	for {
-		select {
-		case stop := <-stopchan:
-			if stop == true {
+		c, ok := l.queue.Pop(stopchan)
+		if !ok {
+			return
+		}
+		// Limit amount of writes to sync behavior with our
+		// BLE implementation.
+		<-l.limiter
+		if l.transport != nil {
+			if err := l.transport.Send(c); err != nil {
+				l.setTransportErr(err)
+				l.Disconnect()
 				return
 			}
-		case b := <-l.wbuffer:
-			// Limit amount of writes to sync behavior with our
-			// BLE implementation.
-			<-l.limiter
-			l.device.FleshlightLaunchFW12Cmd(b[0], b[1])
+			continue
 		}
+		l.device.FleshlightLaunchFW12Cmd(c.Position, c.Speed)
 	}
 }
 
+// setTransportErr records an error from the Transport, so it can be
+// retrieved with TransportErr.
+func (l *buttplugLaunch) setTransportErr(err error) {
+	l.transportMu.Lock()
+	l.transportErr = err
+	l.transportMu.Unlock()
+}
+
+// TransportErr returns the last error returned by the Transport's Send, if
+// any. It is meant for tests built on NewButtplugLaunchWithTransport to
+// observe a ReplayTransport mismatch.
+func (l *buttplugLaunch) TransportErr() error {
+	l.transportMu.Lock()
+	defer l.transportMu.Unlock()
+	return l.transportErr
+}
+
 // Move will move to the specified position at the desired speed.
 // Position and speed are specified in percent.
 func (l *buttplugLaunch) Move(position, speed int) {
@@ -163,8 +268,7 @@ func (l *buttplugLaunch) Move(position, speed int) {
 		speed = 99
 	}
 
-	data := [2]int{position, speed}
-	l.wbuffer <- data
+	l.queue.Push(Command{Position: position, Speed: speed})
 }
 
 // HandleDisconnect registers a function that is called when the Launch
@@ -172,3 +276,27 @@ func (l *buttplugLaunch) Move(position, speed int) {
 func (l *buttplugLaunch) HandleDisconnect(f func()) {
 	l.disconnectFunc = f
 }
+
+// SetQueuePolicy changes what happens to Move calls once the command queue
+// can't keep up.
+func (l *buttplugLaunch) SetQueuePolicy(p Policy) {
+	l.queue.SetPolicy(p)
+}
+
+// QueueStats returns the command queue's current statistics.
+func (l *buttplugLaunch) QueueStats() QueueStats {
+	return l.queue.Stats()
+}
+
+// TouchEvents returns a channel that receives events from the Launch's
+// touch strip. The Buttplug backend does not receive touch notifications
+// from the server, so nothing is ever sent on this channel.
+func (l *buttplugLaunch) TouchEvents() <-chan TouchEvent {
+	return l.touchEvents
+}
+
+// HandleTouch registers a function that is called for every touch event.
+// See TouchEvents for the current Buttplug limitation.
+func (l *buttplugLaunch) HandleTouch(f func(TouchEvent)) {
+	l.touchFunc = f
+}
@@ -0,0 +1,31 @@
+package golaunch
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestOpenButtplugBuildsValidAddr makes sure openButtplug turns a bare
+// "host:port" spec into a URI golibbuttplug.NewClient's url.ParseRequestURI
+// accepts. Nothing is listening on the port, so Connect is expected to fail,
+// but it must fail trying to dial, not parsing the address.
+func TestOpenButtplugBuildsValidAddr(t *testing.T) {
+	u, err := url.Parse("buttplug://127.0.0.1:1")
+	if err != nil {
+		t.Fatalf("url.Parse: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, err = openButtplug(ctx, u)
+	if err == nil {
+		t.Fatal("openButtplug with nothing listening: got nil error, want non-nil")
+	}
+	if strings.Contains(err.Error(), "invalid URI") {
+		t.Fatalf("openButtplug: got %v, want a dial error, not an invalid URI error", err)
+	}
+}
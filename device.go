@@ -1,15 +1,30 @@
-// +build !linux,!darwin
+//go:build !linux && !darwin && !windows
+// +build !linux,!darwin,!windows
 
 package golaunch
 
 import (
+	"context"
 	"fmt"
+	"net/url"
 	"runtime"
 
 	"github.com/go-ble/ble"
 )
 
+func init() {
+	RegisterAdapter("ble", openBLE)
+}
+
 // NewDefaultDevice is platform specific, see ble documentation for details.
 func NewDefaultDevice() (d ble.Device, err error) {
 	return nil, fmt.Errorf("ble not supported on %s", runtime.GOOS)
 }
+
+// openBLE is the "ble" AdapterFactory for platforms without a native BLE
+// backend. It always fails with a descriptive error so Open gives callers
+// the same error they'd get from NewDefaultDevice.
+func openBLE(ctx context.Context, u *url.URL) (Launch, error) {
+	_, err := NewDefaultDevice()
+	return nil, err
+}
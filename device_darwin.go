@@ -1,11 +1,32 @@
 package golaunch
 
 import (
+	"context"
+	"net/url"
+
 	"github.com/go-ble/ble"
 	"github.com/go-ble/ble/darwin"
 )
 
+func init() {
+	RegisterAdapter("ble", openBLE)
+}
+
 // NewDefaultDevice is platform specific, see ble documentation for details.
 func NewDefaultDevice() (d ble.Device, err error) {
 	return darwin.NewDevice()
 }
+
+// openBLE is the "ble" AdapterFactory for platforms with native go-ble
+// support.
+func openBLE(ctx context.Context, u *url.URL) (Launch, error) {
+	d, err := NewDefaultDevice()
+	if err != nil {
+		return nil, err
+	}
+	l := NewLaunchWithDevice(d)
+	if err := l.Connect(ctx); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
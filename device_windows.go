@@ -0,0 +1,266 @@
+package golaunch
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-ble/ble"
+	"tinygo.org/x/bluetooth"
+)
+
+func init() {
+	RegisterAdapter("ble", openBLE)
+}
+
+// NewDefaultDevice always fails on Windows: go-ble has no native backend
+// here, and windowsLaunch talks to the adapter directly through
+// tinygo.org/x/bluetooth instead of a ble.Device. It exists so the
+// platform-agnostic launch.Connect, which calls it when no device was
+// supplied, still links on windows; Connect on a Launch from NewLaunch
+// therefore fails here with this error. Use Open, which goes through
+// openBLE below, to get a working Launch.
+func NewDefaultDevice() (d ble.Device, err error) {
+	return nil, fmt.Errorf("ble: NewDefaultDevice not supported on windows, use golaunch.Open instead")
+}
+
+// The UUIDs in launch.go are go-ble ble.UUID values; tinygo.org/x/bluetooth
+// has its own UUID type, so it gets its own set parsed from the same
+// strings.
+var (
+	winServiceID   = mustParseUUID("88f80580-0000-01e6-aace-0002a5d5c51b")
+	winCmdCharID   = mustParseUUID("88f80581-0000-01e6-aace-0002a5d5c51b")
+	winTouchCharID = mustParseUUID("88f80582-0000-01e6-aace-0002a5d5c51b")
+	winModeCharID  = mustParseUUID("88f80583-0000-01e6-aace-0002a5d5c51b")
+)
+
+// mustParseUUID parses s, panicking if it isn't a valid UUID. It is only
+// ever called with the constants above.
+func mustParseUUID(s string) bluetooth.UUID {
+	u, err := bluetooth.ParseUUID(s)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// windowsLaunch is a Launch implementation for Windows, where go-ble has no
+// backend. It talks to the Launch directly through tinygo.org/x/bluetooth,
+// which wraps WinRT on this platform.
+type windowsLaunch struct {
+	device bluetooth.Device
+	cmd    bluetooth.DeviceCharacteristic
+	mode   bluetooth.DeviceCharacteristic
+	touch  bluetooth.DeviceCharacteristic
+
+	disconnect chan bool
+	queue      *CommandQueue
+	limiter    <-chan time.Time
+
+	touchEvents chan TouchEvent
+
+	disconnectFunc func()
+	touchFunc      func(TouchEvent)
+}
+
+// openBLE is the "ble" AdapterFactory on Windows.
+func openBLE(ctx context.Context, u *url.URL) (Launch, error) {
+	l := &windowsLaunch{
+		disconnect:  make(chan bool),
+		queue:       NewCommandQueue(Block),
+		limiter:     time.Tick(threshold),
+		touchEvents: make(chan TouchEvent, touchEventBufferSize),
+	}
+	if err := l.Connect(ctx); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Connect scans for and connects to a Launch using the default Bluetooth
+// adapter.
+func (l *windowsLaunch) Connect(ctx context.Context) error {
+	adapter := bluetooth.DefaultAdapter
+	if err := adapter.Enable(); err != nil {
+		return err
+	}
+
+	result, err := scanForLaunch(ctx, adapter)
+	if err != nil {
+		return err
+	}
+
+	device, err := adapter.Connect(result.Address, bluetooth.ConnectionParams{})
+	if err != nil {
+		return err
+	}
+	l.device = device
+
+	services, err := device.DiscoverServices([]bluetooth.UUID{winServiceID})
+	if err != nil || len(services) != 1 {
+		return ErrDiscover
+	}
+
+	chars, err := services[0].DiscoverCharacteristics([]bluetooth.UUID{
+		winCmdCharID, winModeCharID, winTouchCharID,
+	})
+	if err != nil {
+		return err
+	}
+	var cmd, mode, touch bluetooth.DeviceCharacteristic
+	var haveCmd, haveMode, haveTouch bool
+	for _, c := range chars {
+		switch c.UUID() {
+		case winCmdCharID:
+			cmd, haveCmd = c, true
+		case winModeCharID:
+			mode, haveMode = c, true
+		case winTouchCharID:
+			touch, haveTouch = c, true
+		}
+	}
+	if !haveCmd || !haveMode {
+		return ErrDiscover
+	}
+	l.cmd = cmd
+	l.mode = mode
+
+	<-time.After(readyTime)
+	if _, err := l.mode.WriteWithoutResponse([]byte{modeReadValuesAsBytes}); err != nil {
+		return ErrInit
+	}
+
+	if haveTouch {
+		l.touch = touch
+		if err := l.touch.EnableNotifications(l.handleTouchNotification); err != nil {
+			return ErrInit
+		}
+	}
+
+	stopWriting := make(chan bool, 1)
+	go func() {
+		<-l.disconnect
+		stopWriting <- true
+		l.device.Disconnect()
+		if l.disconnectFunc != nil {
+			l.disconnectFunc()
+		}
+	}()
+	go l.writeFromBuffer(stopWriting)
+
+	return nil
+}
+
+// scanForLaunch scans with the default adapter until it finds a Launch or
+// ctx is done, in which case it stops the scan and returns ErrDiscover.
+// adapter.Scan blocks until StopScan is called, so this runs it in its own
+// goroutine to be able to abort on ctx.
+func scanForLaunch(ctx context.Context, adapter *bluetooth.Adapter) (bluetooth.ScanResult, error) {
+	found := make(chan bluetooth.ScanResult, 1)
+	scanDone := make(chan error, 1)
+	go func() {
+		scanDone <- adapter.Scan(func(a *bluetooth.Adapter, r bluetooth.ScanResult) {
+			if strings.EqualFold(r.LocalName(), name) {
+				a.StopScan()
+				found <- r
+			}
+		})
+	}()
+
+	select {
+	case r := <-found:
+		return r, nil
+	case <-ctx.Done():
+		adapter.StopScan()
+		<-scanDone
+		return bluetooth.ScanResult{}, ErrDiscover
+	case err := <-scanDone:
+		if err != nil {
+			return bluetooth.ScanResult{}, err
+		}
+		return bluetooth.ScanResult{}, ErrDiscover
+	}
+}
+
+// handleTouchNotification decodes a notification from the touch
+// characteristic and delivers it to TouchEvents and HandleTouch.
+func (l *windowsLaunch) handleTouchNotification(b []byte) {
+	e := decodeTouchEvent(b)
+	select {
+	case l.touchEvents <- e:
+	default:
+	}
+	if l.touchFunc != nil {
+		l.touchFunc(e)
+	}
+}
+
+// Disconnect disconnects from the Launch. The actual cleanup and
+// disconnectFunc callback run once on the background goroutine started by
+// Connect, so calling Disconnect more than once only fires the callback a
+// single time, matching launch.Disconnect.
+func (l *windowsLaunch) Disconnect() {
+	select {
+	case l.disconnect <- true:
+	default:
+	}
+}
+
+// writeFromBuffer sends commands to the Launch that are stored in the
+// command queue.
+func (l *windowsLaunch) writeFromBuffer(stopchan <-chan bool) {
+	for {
+		c, ok := l.queue.Pop(stopchan)
+		if !ok {
+			return
+		}
+		<-l.limiter
+		l.cmd.WriteWithoutResponse([]byte{byte(c.Position), byte(c.Speed)})
+	}
+}
+
+// Move will move to the specified position at the desired speed. Position
+// and speed are specified in percent.
+func (l *windowsLaunch) Move(position, speed int) {
+	if position < 0 {
+		position = 0
+	} else if position > 99 {
+		position = 99
+	}
+	if speed < 20 {
+		speed = 20
+	} else if speed > 99 {
+		speed = 99
+	}
+	l.queue.Push(Command{Position: position, Speed: speed})
+}
+
+// HandleDisconnect registers a function that is called when the Launch
+// disconnects.
+func (l *windowsLaunch) HandleDisconnect(f func()) {
+	l.disconnectFunc = f
+}
+
+// SetQueuePolicy changes what happens to Move calls once the command queue
+// can't keep up.
+func (l *windowsLaunch) SetQueuePolicy(p Policy) {
+	l.queue.SetPolicy(p)
+}
+
+// QueueStats returns the command queue's current statistics.
+func (l *windowsLaunch) QueueStats() QueueStats {
+	return l.queue.Stats()
+}
+
+// TouchEvents returns a channel that receives events from the Launch's
+// touch strip.
+func (l *windowsLaunch) TouchEvents() <-chan TouchEvent {
+	return l.touchEvents
+}
+
+// HandleTouch registers a function that is called for every touch event.
+func (l *windowsLaunch) HandleTouch(f func(TouchEvent)) {
+	l.touchFunc = f
+}
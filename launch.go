@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-ble/ble"
@@ -43,6 +44,8 @@ var (
 	threshold = time.Millisecond * 100
 	// Amount of write events to buffer before blocking.
 	writeBufferSize = 10
+	// Amount of touch events to buffer before new ones are dropped.
+	touchEventBufferSize = 10
 )
 
 const (
@@ -65,15 +68,31 @@ type Launch interface {
 
 	// HandleDisconnect registers a function to call when a device disconnects
 	HandleDisconnect(f func())
+
+	// TouchEvents returns a channel that receives events from the Launch's
+	// touch strip. It is only populated once Connect has succeeded.
+	TouchEvents() <-chan TouchEvent
+
+	// HandleTouch registers a function to call for every touch event,
+	// symmetric to HandleDisconnect.
+	HandleTouch(f func(TouchEvent))
+
+	// SetQueuePolicy changes what happens to Move calls once the command
+	// queue can't keep up.
+	SetQueuePolicy(p Policy)
+
+	// QueueStats returns the command queue's current statistics.
+	QueueStats() QueueStats
 }
 
 // NewLaunch creates and returns a Launch client that can be used to
 // communicate.
 func NewLaunch() Launch {
 	l := &launch{
-		disconnect: make(chan bool),
-		wbuffer:    make(chan [2]byte, writeBufferSize),
-		limiter:    time.Tick(threshold),
+		disconnect:  make(chan bool),
+		queue:       NewCommandQueue(Block),
+		limiter:     time.Tick(threshold),
+		touchEvents: make(chan TouchEvent, touchEventBufferSize),
 	}
 	return l
 }
@@ -88,24 +107,57 @@ func NewLaunchWithDevice(d ble.Device) Launch {
 
 }
 
+// NewLaunchWithTransport creates a Launch client that sends every Move over
+// the given Transport instead of a real Bluetooth connection. It is meant
+// for deterministic testing.
+func NewLaunchWithTransport(t Transport) Launch {
+	l, _ := NewLaunch().(*launch)
+	l.transport = t
+	return l
+}
+
 // launch is the structure used to manage the connection to a Launch.
 type launch struct {
 	device ble.Device
 	client ble.Client
 
-	cmd  *ble.Characteristic
-	mode *ble.Characteristic
+	cmd   *ble.Characteristic
+	mode  *ble.Characteristic
+	touch *ble.Characteristic
 
 	disconnect chan bool
-	wbuffer    chan [2]byte
+	queue      *CommandQueue
 	limiter    <-chan time.Time
 
+	// transport, if set, replaces the real Bluetooth connection.
+	transport Transport
+
+	transportMu  sync.Mutex
+	transportErr error
+
+	touchEvents chan TouchEvent
+
 	disconnectFunc func()
+	touchFunc      func(TouchEvent)
 }
 
 // Connect initializes configured Bluetooth device and creates a connection to
 // a Launch.
 func (l *launch) Connect(ctx context.Context) (err error) {
+	// A Transport replaces the real Bluetooth connection entirely.
+	if l.transport != nil {
+		stopWriting := make(chan bool, 1)
+		go func() {
+			<-l.disconnect
+			stopWriting <- true
+			if l.disconnectFunc != nil {
+				l.disconnectFunc()
+			}
+		}()
+		go l.writeFromBuffer(stopWriting)
+		return nil
+	}
+
 	// Claim a Bluetooth device
 	if l.device == nil {
 		l.device, err = NewDefaultDevice()
@@ -137,8 +189,17 @@ func (l *launch) Connect(ctx context.Context) (err error) {
 		return ErrInit
 	}
 
+	// Subscribe to touch notifications, if the Launch exposes them
+	if l.touch != nil {
+		if err := l.client.Subscribe(l.touch, false, l.handleTouchNotification); err != nil {
+			l.client.CancelConnection()
+			l.cleanupClient()
+			return ErrInit
+		}
+	}
+
 	// Handle disconnects
-	stopWriting := make(chan bool)
+	stopWriting := make(chan bool, 1)
 	go func() {
 		select {
 		case <-l.client.Disconnected():
@@ -177,17 +238,19 @@ func (l *launch) discover(ctx context.Context) error {
 
 	// Discover Launch characteristics
 	cs, err := client.DiscoverCharacteristics(
-		[]ble.UUID{cmdCharID, modeCharID}, s[0])
+		[]ble.UUID{cmdCharID, modeCharID, touchCharID}, s[0])
 	if err != nil {
 		return err
 	}
-	var cmd, mode *ble.Characteristic
+	var cmd, mode, touch *ble.Characteristic
 	for _, c := range cs {
 		switch {
 		case c.UUID.Equal(cmdCharID):
 			cmd = c
 		case c.UUID.Equal(modeCharID):
 			mode = c
+		case c.UUID.Equal(touchCharID):
+			touch = c
 		}
 	}
 	if cmd == nil || mode == nil {
@@ -198,6 +261,7 @@ func (l *launch) discover(ctx context.Context) error {
 	l.client = client
 	l.cmd = cmd
 	l.mode = mode
+	l.touch = touch
 
 	return nil
 }
@@ -216,6 +280,7 @@ func (l *launch) cleanupClient() {
 		l.client = nil
 		l.mode = nil
 		l.cmd = nil
+		l.touch = nil
 	}
 }
 
@@ -241,23 +306,47 @@ func (l *launch) writeMode(c byte) error {
 	return ErrUnknownMode
 }
 
-// writeFromBuffer sends commands to the Launch that are stored in the write
-// buffer. To stop this function send true on the l.stopWriting channel.
+// writeFromBuffer sends commands to the Launch that are stored in the
+// command queue. To stop this function send true on the l.stopWriting
+// channel.
 func (l *launch) writeFromBuffer(stopchan <-chan bool) {
 	for {
-		select {
-		case stop := <-stopchan:
-			if stop == true {
+		c, ok := l.queue.Pop(stopchan)
+		if !ok {
+			return
+		}
+		// Limit amount of writes to avoid disconnects
+		<-l.limiter
+		if l.transport != nil {
+			if err := l.transport.Send(c); err != nil {
+				l.setTransportErr(err)
+				l.Disconnect()
 				return
 			}
-		case b := <-l.wbuffer:
-			// Limit amount of writes to avoid disconnects
-			<-l.limiter
-			l.client.WriteCharacteristic(l.cmd, b[:], true)
+			continue
 		}
+		data := [2]byte{byte(c.Position), byte(c.Speed)}
+		l.client.WriteCharacteristic(l.cmd, data[:], true)
 	}
 }
 
+// setTransportErr records an error from the Transport, so it can be
+// retrieved with TransportErr.
+func (l *launch) setTransportErr(err error) {
+	l.transportMu.Lock()
+	l.transportErr = err
+	l.transportMu.Unlock()
+}
+
+// TransportErr returns the last error returned by the Transport's Send, if
+// any. It is meant for tests built on NewLaunchWithTransport to observe a
+// ReplayTransport mismatch.
+func (l *launch) TransportErr() error {
+	l.transportMu.Lock()
+	defer l.transportMu.Unlock()
+	return l.transportErr
+}
+
 // Move will move to the specified position at the desired speed.
 // Position and speed are specified in percent.
 func (l *launch) Move(position, speed int) {
@@ -275,8 +364,18 @@ func (l *launch) Move(position, speed int) {
 		speed = 99
 	}
 
-	data := [2]byte{byte(position), byte(speed)}
-	l.wbuffer <- data
+	l.queue.Push(Command{Position: position, Speed: speed})
+}
+
+// SetQueuePolicy changes what happens to Move calls once the command queue
+// can't keep up.
+func (l *launch) SetQueuePolicy(p Policy) {
+	l.queue.SetPolicy(p)
+}
+
+// QueueStats returns the command queue's current statistics.
+func (l *launch) QueueStats() QueueStats {
+	return l.queue.Stats()
 }
 
 // HandleDisconnect registers a function that is called when the Launch
@@ -284,3 +383,27 @@ func (l *launch) Move(position, speed int) {
 func (l *launch) HandleDisconnect(f func()) {
 	l.disconnectFunc = f
 }
+
+// handleTouchNotification decodes a notification from the touch
+// characteristic and delivers it to TouchEvents and HandleTouch.
+func (l *launch) handleTouchNotification(req []byte) {
+	e := decodeTouchEvent(req)
+	select {
+	case l.touchEvents <- e:
+	default:
+	}
+	if l.touchFunc != nil {
+		l.touchFunc(e)
+	}
+}
+
+// TouchEvents returns a channel that receives events from the Launch's
+// touch strip.
+func (l *launch) TouchEvents() <-chan TouchEvent {
+	return l.touchEvents
+}
+
+// HandleTouch registers a function that is called for every touch event.
+func (l *launch) HandleTouch(f func(TouchEvent)) {
+	l.touchFunc = f
+}
@@ -0,0 +1,136 @@
+package golaunch
+
+import (
+	"context"
+	"net/url"
+	"sync"
+)
+
+func init() {
+	RegisterAdapter("mock", openMock)
+}
+
+// openMock is the "mock" AdapterFactory. It returns a MockLaunch so callers
+// can write backend-agnostic code that is still exercised in tests.
+func openMock(ctx context.Context, u *url.URL) (Launch, error) {
+	l := NewMockLaunch()
+	if err := l.Connect(ctx); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// MockLaunch is a Launch that records every Move instead of sending it
+// anywhere, for use in tests.
+type MockLaunch struct {
+	mu sync.Mutex
+
+	connected      bool
+	moves          []MockMove
+	disconnectFunc func()
+
+	touchEvents chan TouchEvent
+	touchFunc   func(TouchEvent)
+
+	policy Policy
+}
+
+// MockMove records the position and speed of a single call to Move.
+type MockMove struct {
+	Position int
+	Speed    int
+}
+
+// NewMockLaunch creates a Launch that records Moves in memory instead of
+// sending them to a device.
+func NewMockLaunch() *MockLaunch {
+	return &MockLaunch{
+		touchEvents: make(chan TouchEvent, touchEventBufferSize),
+	}
+}
+
+// Connect marks the mock as connected. It never fails.
+func (l *MockLaunch) Connect(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.connected = true
+	return nil
+}
+
+// Disconnect marks the mock as disconnected and calls the disconnect
+// handler, if any.
+func (l *MockLaunch) Disconnect() {
+	l.mu.Lock()
+	l.connected = false
+	f := l.disconnectFunc
+	l.mu.Unlock()
+	if f != nil {
+		f()
+	}
+}
+
+// Move records position and speed.
+func (l *MockLaunch) Move(position, speed int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.moves = append(l.moves, MockMove{Position: position, Speed: speed})
+}
+
+// HandleDisconnect registers a function that is called when Disconnect is
+// called.
+func (l *MockLaunch) HandleDisconnect(f func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.disconnectFunc = f
+}
+
+// Moves returns every Move recorded so far, in order.
+func (l *MockLaunch) Moves() []MockMove {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	moves := make([]MockMove, len(l.moves))
+	copy(moves, l.moves)
+	return moves
+}
+
+// TouchEvents returns a channel that receives events from the Launch's
+// touch strip.
+func (l *MockLaunch) TouchEvents() <-chan TouchEvent {
+	return l.touchEvents
+}
+
+// HandleTouch registers a function that is called for every touch event.
+func (l *MockLaunch) HandleTouch(f func(TouchEvent)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.touchFunc = f
+}
+
+// Touch injects a touch event, as if it came from a real Launch. It is
+// meant for tests.
+func (l *MockLaunch) Touch(e TouchEvent) {
+	l.mu.Lock()
+	f := l.touchFunc
+	l.mu.Unlock()
+	select {
+	case l.touchEvents <- e:
+	default:
+	}
+	if f != nil {
+		f(e)
+	}
+}
+
+// SetQueuePolicy records the policy for inspection by tests. The mock
+// applies every Move synchronously, so no policy changes its behavior.
+func (l *MockLaunch) SetQueuePolicy(p Policy) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.policy = p
+}
+
+// QueueStats always reports an empty queue, since the mock applies Moves
+// synchronously and never builds up a backlog.
+func (l *MockLaunch) QueueStats() QueueStats {
+	return QueueStats{}
+}
@@ -0,0 +1,60 @@
+package golaunch
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMockLaunchRecordsMoves(t *testing.T) {
+	l := NewMockLaunch()
+	if err := l.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+
+	l.Move(10, 30)
+	l.Move(50, 60)
+
+	want := []MockMove{{Position: 10, Speed: 30}, {Position: 50, Speed: 60}}
+	moves := l.Moves()
+	if len(moves) != len(want) {
+		t.Fatalf("Moves() = %+v, want %+v", moves, want)
+	}
+	for i := range want {
+		if moves[i] != want[i] {
+			t.Fatalf("Moves()[%d] = %+v, want %+v", i, moves[i], want[i])
+		}
+	}
+}
+
+func TestMockLaunchHandleDisconnect(t *testing.T) {
+	l := NewMockLaunch()
+	called := false
+	l.HandleDisconnect(func() { called = true })
+
+	l.Disconnect()
+
+	if !called {
+		t.Fatal("HandleDisconnect callback was not invoked by Disconnect")
+	}
+}
+
+func TestMockLaunchTouch(t *testing.T) {
+	l := NewMockLaunch()
+	var got TouchEvent
+	l.HandleTouch(func(e TouchEvent) { got = e })
+
+	want := TouchEvent{Kind: Slider, Value: 42}
+	l.Touch(want)
+
+	if got != want {
+		t.Fatalf("HandleTouch callback got %+v, want %+v", got, want)
+	}
+	select {
+	case e := <-l.TouchEvents():
+		if e != want {
+			t.Fatalf("TouchEvents() = %+v, want %+v", e, want)
+		}
+	default:
+		t.Fatal("TouchEvents() had nothing queued after Touch")
+	}
+}
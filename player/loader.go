@@ -0,0 +1,77 @@
+package player
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"sort"
+	"time"
+)
+
+// funscript is the current Funscript v1 file format.
+type funscript struct {
+	Actions []struct {
+		At  int64 `json:"at"`
+		Pos int   `json:"pos"`
+	} `json:"actions"`
+}
+
+// launchscript is the legacy format produced by the original Launch
+// application, predating Funscript.
+type launchscript struct {
+	Scripts []struct {
+		Actions []struct {
+			Time int64 `json:"time"`
+			Pos  int   `json:"pos"`
+		} `json:"actions"`
+	} `json:"scripts"`
+}
+
+// Load reads and parses a script, recognizing both current Funscript v1
+// and the legacy Launchscript format.
+func Load(r io.Reader) (*Script, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var fs funscript
+	if err := json.Unmarshal(data, &fs); err == nil && len(fs.Actions) > 0 {
+		s := &Script{Actions: make([]Action, len(fs.Actions))}
+		for i, a := range fs.Actions {
+			s.Actions[i] = Action{
+				At:  time.Duration(a.At) * time.Millisecond,
+				Pos: a.Pos,
+			}
+		}
+		sortActions(s.Actions)
+		return s, nil
+	}
+
+	var ls launchscript
+	if err := json.Unmarshal(data, &ls); err == nil && len(ls.Scripts) > 0 {
+		var actions []Action
+		for _, sc := range ls.Scripts {
+			for _, a := range sc.Actions {
+				actions = append(actions, Action{
+					At:  time.Duration(a.Time) * time.Millisecond,
+					Pos: a.Pos,
+				})
+			}
+		}
+		if len(actions) > 0 {
+			sortActions(actions)
+			return &Script{Actions: actions}, nil
+		}
+	}
+
+	return nil, ErrInvalidScript
+}
+
+// sortActions orders actions by their timestamp, as callers depend on this
+// when seeking and scheduling.
+func sortActions(actions []Action) {
+	sort.Slice(actions, func(i, j int) bool {
+		return actions[i].At < actions[j].At
+	})
+}
@@ -0,0 +1,151 @@
+package player
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/funjack/golaunch"
+)
+
+// Player schedules the Actions of a Script onto a golaunch.Launch, issuing
+// each Move at the right wall-clock time.
+type Player struct {
+	launch golaunch.Launch
+	script *Script
+
+	mu      sync.Mutex
+	playing bool
+	pos     time.Duration
+	idx     int
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// New creates a Player that will drive launch according to script.
+func New(launch golaunch.Launch, script *Script) *Player {
+	return &Player{
+		launch: launch,
+		script: script,
+	}
+}
+
+// Play starts (or resumes) playback from the current position. It is a
+// no-op if already playing.
+func (p *Player) Play() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.playing {
+		return
+	}
+	p.playing = true
+	p.startRun()
+}
+
+// Pause stops playback, keeping the current position so Play can resume
+// from it. It waits for the running goroutine to exit before returning, so
+// a subsequent Play cannot race with it over p.idx/p.pos.
+func (p *Player) Pause() {
+	p.mu.Lock()
+	if !p.playing {
+		p.mu.Unlock()
+		return
+	}
+	p.playing = false
+	cancel, done := p.cancel, p.done
+	p.cancel = nil
+	p.mu.Unlock()
+
+	cancel()
+	<-done
+}
+
+// Seek moves the playback position to offset, measured from the start of
+// the script. If the Player is playing, playback continues from there right
+// away; otherwise it does when Play is next called.
+func (p *Player) Seek(offset time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.playing {
+		cancel, done := p.cancel, p.done
+		p.mu.Unlock()
+		cancel()
+		<-done
+		p.mu.Lock()
+	}
+	p.pos = offset
+	p.idx = indexAfter(p.script.Actions, offset)
+	if p.playing {
+		p.startRun()
+	}
+}
+
+// startRun starts the goroutine that drives playback from p.idx/p.pos. The
+// caller must hold p.mu and have already joined any previous run via its
+// done channel.
+func (p *Player) startRun() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	p.cancel = cancel
+	p.done = done
+	go p.run(ctx, p.idx, p.pos, done)
+}
+
+// run plays the script starting at idx/pos until it reaches the end or ctx
+// is canceled, then closes done.
+func (p *Player) run(ctx context.Context, idx int, pos time.Duration, done chan struct{}) {
+	defer close(done)
+
+	actions := p.script.Actions
+
+	start := time.Now().Add(-pos)
+	lastAt := pos
+	lastPos := 0
+	if idx > 0 {
+		lastPos = actions[idx-1].Pos
+	}
+
+	timer := time.NewTimer(0)
+	if !timer.Stop() {
+		<-timer.C
+	}
+	defer timer.Stop()
+
+	for i := idx; i < len(actions); i++ {
+		a := actions[i]
+		if wait := a.At - time.Since(start); wait > 0 {
+			timer.Reset(wait)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		speed := speedFor(a.Pos-lastPos, a.At-lastAt)
+		p.launch.Move(a.Pos, speed)
+
+		lastPos, lastAt = a.Pos, a.At
+		p.mu.Lock()
+		if ctx.Err() != nil {
+			p.mu.Unlock()
+			return
+		}
+		p.idx, p.pos = i+1, a.At
+		p.mu.Unlock()
+	}
+
+	p.mu.Lock()
+	p.playing = false
+	p.mu.Unlock()
+}
+
+// indexAfter returns the index of the first action at or after offset.
+func indexAfter(actions []Action, offset time.Duration) int {
+	for i, a := range actions {
+		if a.At >= offset {
+			return i
+		}
+	}
+	return len(actions)
+}
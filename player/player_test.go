@@ -0,0 +1,89 @@
+package player
+
+import (
+	"testing"
+	"time"
+
+	"github.com/funjack/golaunch"
+)
+
+func TestPlayerPlaysActionsInOrder(t *testing.T) {
+	l := golaunch.NewMockLaunch()
+	p := New(l, &Script{Actions: []Action{
+		{At: 0, Pos: 0},
+		{At: 10 * time.Millisecond, Pos: 50},
+		{At: 20 * time.Millisecond, Pos: 99},
+	}})
+
+	p.Play()
+	waitForMoves(t, l, 3)
+	p.Pause()
+
+	moves := l.Moves()
+	want := []int{0, 50, 99}
+	for i, pos := range want {
+		if moves[i].Position != pos {
+			t.Fatalf("Moves()[%d].Position = %d, want %d", i, moves[i].Position, pos)
+		}
+	}
+}
+
+func TestPlayerPauseJoinsRun(t *testing.T) {
+	l := golaunch.NewMockLaunch()
+	p := New(l, &Script{Actions: []Action{
+		{At: 0, Pos: 0},
+		{At: 50 * time.Millisecond, Pos: 99},
+	}})
+
+	p.Play()
+	waitForMoves(t, l, 1)
+	p.Pause()
+
+	// Pause must have joined the run goroutine before returning, so no
+	// further Move can appear afterwards.
+	n := len(l.Moves())
+	time.Sleep(100 * time.Millisecond)
+	if got := len(l.Moves()); got != n {
+		t.Fatalf("Moves() grew from %d to %d after Pause returned", n, got)
+	}
+}
+
+func TestPlayerSeekResumesFromOffset(t *testing.T) {
+	l := golaunch.NewMockLaunch()
+	p := New(l, &Script{Actions: []Action{
+		{At: 0, Pos: 0},
+		{At: 10 * time.Millisecond, Pos: 30},
+		{At: 20 * time.Millisecond, Pos: 60},
+		{At: 30 * time.Millisecond, Pos: 99},
+	}})
+
+	p.Seek(15 * time.Millisecond)
+	p.Play()
+	waitForMoves(t, l, 2)
+	p.Pause()
+
+	moves := l.Moves()
+	want := []int{60, 99}
+	for i, pos := range want {
+		if moves[i].Position != pos {
+			t.Fatalf("Moves()[%d].Position = %d, want %d", i, moves[i].Position, pos)
+		}
+	}
+}
+
+// waitForMoves blocks until l has recorded at least n Moves or a deadline
+// passes.
+func waitForMoves(t *testing.T, l *golaunch.MockLaunch, n int) {
+	t.Helper()
+	deadline := time.After(time.Second)
+	for {
+		if len(l.Moves()) >= n {
+			return
+		}
+		select {
+		case <-deadline:
+			t.Fatalf("Moves() never reached %d entries", n)
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,50 @@
+// Package player implements a Funscript playback engine that drives a
+// golaunch.Launch with correctly timed Move calls.
+package player
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// ErrInvalidScript is the error returned when a script could not be
+// recognized as either Funscript or the legacy Launchscript format.
+var ErrInvalidScript = errors.New("could not parse script")
+
+// Action is a single point in a Script: move to Pos (0-100) at time At,
+// measured from the start of playback.
+type Action struct {
+	At  time.Duration
+	Pos int
+}
+
+// Script is an ordered, time-sorted list of Actions.
+type Script struct {
+	Actions []Action
+}
+
+// speedFor computes the Launch speed (20-99) needed to travel distance
+// percent in duration, using the empirically established Fleshlight speed
+// curve: speed% ≈ 25000 · distance^-1.05 / duration_ms.
+func speedFor(distance int, duration time.Duration) int {
+	if distance < 0 {
+		distance = -distance
+	}
+	ms := float64(duration / time.Millisecond)
+	if distance == 0 || ms <= 0 {
+		return 20
+	}
+	speed := 25000 * math.Pow(float64(distance), -1.05) / ms
+	return clampSpeed(int(math.Round(speed)))
+}
+
+// clampSpeed keeps speed within the range the Launch can safely handle.
+func clampSpeed(speed int) int {
+	if speed < 20 {
+		return 20
+	} else if speed > 99 {
+		return 99
+	}
+	return speed
+}
@@ -0,0 +1,78 @@
+package player
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSpeedFor(t *testing.T) {
+	if got := speedFor(0, 500*time.Millisecond); got != 20 {
+		t.Fatalf("speedFor(0, 500ms) = %d, want 20 (zero distance clamps to minimum)", got)
+	}
+	if got := speedFor(10, 0); got != 20 {
+		t.Fatalf("speedFor(10, 0) = %d, want 20 (zero duration clamps to minimum)", got)
+	}
+	if got, want := speedFor(-10, 100*time.Millisecond), speedFor(10, 100*time.Millisecond); got != want {
+		t.Fatalf("speedFor(-10, ...) = %d, want %d (same as positive distance)", got, want)
+	}
+}
+
+func TestClampSpeed(t *testing.T) {
+	tests := []struct {
+		speed, want int
+	}{
+		{0, 20},
+		{19, 20},
+		{20, 20},
+		{50, 50},
+		{99, 99},
+		{100, 99},
+	}
+	for _, tt := range tests {
+		if got := clampSpeed(tt.speed); got != tt.want {
+			t.Fatalf("clampSpeed(%d) = %d, want %d", tt.speed, got, tt.want)
+		}
+	}
+}
+
+func TestLoadFunscript(t *testing.T) {
+	s, err := Load(strings.NewReader(`{"actions":[{"at":500,"pos":50},{"at":0,"pos":0}]}`))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	want := []Action{{At: 0, Pos: 0}, {At: 500 * time.Millisecond, Pos: 50}}
+	if len(s.Actions) != len(want) {
+		t.Fatalf("Actions = %+v, want %+v", s.Actions, want)
+	}
+	for i := range want {
+		if s.Actions[i] != want[i] {
+			t.Fatalf("Actions[%d] = %+v, want %+v", i, s.Actions[i], want[i])
+		}
+	}
+}
+
+func TestLoadLaunchscript(t *testing.T) {
+	s, err := Load(strings.NewReader(`{"scripts":[{"actions":[{"time":500,"pos":50},{"time":0,"pos":0}]}]}`))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	want := []Action{{At: 0, Pos: 0}, {At: 500 * time.Millisecond, Pos: 50}}
+	if len(s.Actions) != len(want) {
+		t.Fatalf("Actions = %+v, want %+v", s.Actions, want)
+	}
+	for i := range want {
+		if s.Actions[i] != want[i] {
+			t.Fatalf("Actions[%d] = %+v, want %+v", i, s.Actions[i], want[i])
+		}
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	if _, err := Load(strings.NewReader(`not json`)); err != ErrInvalidScript {
+		t.Fatalf("Load(invalid) error = %v, want %v", err, ErrInvalidScript)
+	}
+	if _, err := Load(strings.NewReader(`{}`)); err != ErrInvalidScript {
+		t.Fatalf("Load({}) error = %v, want %v", err, ErrInvalidScript)
+	}
+}
@@ -0,0 +1,202 @@
+package golaunch
+
+import (
+	"sync"
+	"time"
+)
+
+// Policy controls what a CommandQueue does when it can't keep up with
+// incoming commands.
+type Policy int
+
+const (
+	// Block makes Push wait for room, exerting back-pressure on the
+	// caller. This is the original, default behavior.
+	Block Policy = iota
+	// DropOldest discards the oldest queued command to make room for the
+	// newest one. Critical for real-time scripts, where a stale target is
+	// worse than a missed one.
+	DropOldest
+	// Coalesce collapses a run of commands into the latest one once the
+	// queue is full, i.e. once the rate limiter can't keep up.
+	Coalesce
+	// DeadlineDrop discards queued commands older than queueDeadline
+	// instead of sending them late, and also falls back to dropping the
+	// oldest command once the queue is at capacity, same as DropOldest.
+	DeadlineDrop
+)
+
+// queueDeadline is how old a command may get before DeadlineDrop discards
+// it.
+var queueDeadline = time.Millisecond * 250
+
+// QueueStats reports what a CommandQueue has done so far.
+type QueueStats struct {
+	Dropped   int
+	Coalesced int
+	Depth     int
+}
+
+// queuedCommand pairs a Command with the time it was pushed, so
+// DeadlineDrop can tell how old it is.
+type queuedCommand struct {
+	cmd Command
+	at  time.Time
+	// tookToken records whether this item consumed a q.space token when it
+	// was pushed under the Block policy. Whoever removes the item later
+	// must hand that token back, regardless of the queue's policy at
+	// removal time: SetPolicy can move the queue away from Block while a
+	// Push is still parked on q.space, and only releasing per-item, not
+	// per-current-policy, guarantees that Push eventually wakes.
+	tookToken bool
+}
+
+// CommandQueue buffers commands between Move and the goroutine that writes
+// them to the wire, applying a Policy once it can't keep up.
+type CommandQueue struct {
+	mu       sync.Mutex
+	policy   Policy
+	capacity int
+	items    []queuedCommand
+	stats    QueueStats
+
+	space chan struct{}
+	ready chan struct{}
+}
+
+// NewCommandQueue creates a CommandQueue with the given Policy and the
+// library's default capacity.
+func NewCommandQueue(policy Policy) *CommandQueue {
+	q := &CommandQueue{
+		policy:   policy,
+		capacity: writeBufferSize,
+		space:    make(chan struct{}, writeBufferSize),
+		ready:    make(chan struct{}, 1),
+	}
+	for i := 0; i < writeBufferSize; i++ {
+		q.space <- struct{}{}
+	}
+	return q
+}
+
+// SetPolicy changes how the queue behaves once it is full.
+func (q *CommandQueue) SetPolicy(p Policy) {
+	q.mu.Lock()
+	q.policy = p
+	q.mu.Unlock()
+}
+
+// Stats returns the queue's current statistics.
+func (q *CommandQueue) Stats() QueueStats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.stats
+}
+
+// Push enqueues c, applying the current Policy if the queue is full. Under
+// Block it waits for room, exactly like sending on the old raw channel did.
+func (q *CommandQueue) Push(c Command) {
+	q.mu.Lock()
+	policy := q.policy
+	q.mu.Unlock()
+
+	tookToken := false
+	if policy == Block {
+		<-q.space
+		tookToken = true
+	}
+
+	item := queuedCommand{c, time.Now(), tookToken}
+
+	q.mu.Lock()
+	switch policy {
+	case DropOldest:
+		if len(q.items) >= q.capacity {
+			q.release(q.items[0].tookToken)
+			q.items = q.items[1:]
+			q.stats.Dropped++
+		}
+		q.items = append(q.items, item)
+	case Coalesce:
+		if len(q.items) >= q.capacity {
+			q.release(q.items[len(q.items)-1].tookToken)
+			q.items[len(q.items)-1] = item
+			q.stats.Coalesced++
+		} else {
+			q.items = append(q.items, item)
+		}
+	case DeadlineDrop:
+		q.pruneStale()
+		if len(q.items) >= q.capacity {
+			q.release(q.items[0].tookToken)
+			q.items = q.items[1:]
+			q.stats.Dropped++
+		}
+		q.items = append(q.items, item)
+	default: // Block
+		q.items = append(q.items, item)
+	}
+	q.stats.Depth = len(q.items)
+	q.mu.Unlock()
+
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+// release hands a q.space token back if tookToken is set, i.e. the item
+// being removed consumed one when it was pushed under the Block policy.
+// Callers must hold q.mu.
+func (q *CommandQueue) release(tookToken bool) {
+	if !tookToken {
+		return
+	}
+	select {
+	case q.space <- struct{}{}:
+	default:
+	}
+}
+
+// pruneStale drops items older than queueDeadline. Callers must hold q.mu.
+func (q *CommandQueue) pruneStale() {
+	cutoff := time.Now().Add(-queueDeadline)
+	i := 0
+	for i < len(q.items) && q.items[i].at.Before(cutoff) {
+		q.release(q.items[i].tookToken)
+		i++
+	}
+	if i > 0 {
+		q.stats.Dropped += i
+		q.items = q.items[i:]
+		q.stats.Depth = len(q.items)
+	}
+}
+
+// Pop waits for a command or for true to be sent on stopchan, in which case
+// it returns ok=false.
+func (q *CommandQueue) Pop(stopchan <-chan bool) (c Command, ok bool) {
+	for {
+		q.mu.Lock()
+		if q.policy == DeadlineDrop {
+			q.pruneStale()
+		}
+		if len(q.items) > 0 {
+			item := q.items[0]
+			q.items = q.items[1:]
+			q.stats.Depth = len(q.items)
+			q.release(item.tookToken)
+			q.mu.Unlock()
+			return item.cmd, true
+		}
+		q.mu.Unlock()
+
+		select {
+		case <-q.ready:
+		case stop := <-stopchan:
+			if stop == true {
+				return Command{}, false
+			}
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package golaunch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCommandQueueDropOldest(t *testing.T) {
+	q := NewCommandQueue(DropOldest)
+	q.capacity = 2
+
+	q.Push(Command{Position: 1})
+	q.Push(Command{Position: 2})
+	q.Push(Command{Position: 3})
+
+	if stats := q.Stats(); stats.Dropped != 1 {
+		t.Fatalf("Stats().Dropped = %d, want 1", stats.Dropped)
+	}
+
+	stopchan := make(chan bool)
+	c, ok := q.Pop(stopchan)
+	if !ok || c.Position != 2 {
+		t.Fatalf("Pop() = %+v, %v, want {Position:2}, true", c, ok)
+	}
+	c, ok = q.Pop(stopchan)
+	if !ok || c.Position != 3 {
+		t.Fatalf("Pop() = %+v, %v, want {Position:3}, true", c, ok)
+	}
+}
+
+func TestCommandQueueCoalesce(t *testing.T) {
+	q := NewCommandQueue(Coalesce)
+	q.capacity = 2
+
+	q.Push(Command{Position: 1})
+	q.Push(Command{Position: 2})
+	q.Push(Command{Position: 3})
+
+	if stats := q.Stats(); stats.Coalesced != 1 {
+		t.Fatalf("Stats().Coalesced = %d, want 1", stats.Coalesced)
+	}
+
+	stopchan := make(chan bool)
+	c, ok := q.Pop(stopchan)
+	if !ok || c.Position != 1 {
+		t.Fatalf("Pop() = %+v, %v, want {Position:1}, true", c, ok)
+	}
+	c, ok = q.Pop(stopchan)
+	if !ok || c.Position != 3 {
+		t.Fatalf("Pop() = %+v, %v, want {Position:3}, true", c, ok)
+	}
+}
+
+func TestCommandQueueDeadlineDrop(t *testing.T) {
+	q := NewCommandQueue(DeadlineDrop)
+
+	q.Push(Command{Position: 1})
+	time.Sleep(queueDeadline + 10*time.Millisecond)
+	q.Push(Command{Position: 2})
+
+	if stats := q.Stats(); stats.Dropped != 1 {
+		t.Fatalf("Stats().Dropped = %d, want 1", stats.Dropped)
+	}
+
+	stopchan := make(chan bool)
+	c, ok := q.Pop(stopchan)
+	if !ok || c.Position != 2 {
+		t.Fatalf("Pop() = %+v, %v, want {Position:2}, true", c, ok)
+	}
+}
+
+// TestCommandQueueDeadlineDropRespectsCapacity covers pushing faster than
+// queueDeadline can reap, where age alone never drops anything: the queue
+// must still cap itself at capacity like DropOldest does.
+func TestCommandQueueDeadlineDropRespectsCapacity(t *testing.T) {
+	q := NewCommandQueue(DeadlineDrop)
+	q.capacity = 2
+
+	q.Push(Command{Position: 1})
+	q.Push(Command{Position: 2})
+	q.Push(Command{Position: 3})
+
+	if stats := q.Stats(); stats.Depth != 2 {
+		t.Fatalf("Stats().Depth = %d, want 2", stats.Depth)
+	}
+	if stats := q.Stats(); stats.Dropped != 1 {
+		t.Fatalf("Stats().Dropped = %d, want 1", stats.Dropped)
+	}
+
+	stopchan := make(chan bool)
+	c, ok := q.Pop(stopchan)
+	if !ok || c.Position != 2 {
+		t.Fatalf("Pop() = %+v, %v, want {Position:2}, true", c, ok)
+	}
+	c, ok = q.Pop(stopchan)
+	if !ok || c.Position != 3 {
+		t.Fatalf("Pop() = %+v, %v, want {Position:3}, true", c, ok)
+	}
+}
+
+func TestCommandQueueStatsDepth(t *testing.T) {
+	q := NewCommandQueue(DropOldest)
+
+	q.Push(Command{Position: 1})
+	q.Push(Command{Position: 2})
+
+	if stats := q.Stats(); stats.Depth != 2 {
+		t.Fatalf("Stats().Depth = %d, want 2", stats.Depth)
+	}
+
+	stopchan := make(chan bool)
+	if _, ok := q.Pop(stopchan); !ok {
+		t.Fatal("Pop() = false, want true")
+	}
+
+	if stats := q.Stats(); stats.Depth != 1 {
+		t.Fatalf("Stats().Depth = %d, want 1", stats.Depth)
+	}
+}
+
+func TestCommandQueuePopStop(t *testing.T) {
+	q := NewCommandQueue(Block)
+	stopchan := make(chan bool, 1)
+	stopchan <- true
+
+	if _, ok := q.Pop(stopchan); ok {
+		t.Fatal("Pop() on a stopped queue: got ok=true, want false")
+	}
+}
+
+// TestCommandQueueSetPolicyUnblocksBlockedPush covers switching away from
+// Block while a Push is parked waiting for room. Pop must keep handing back
+// the q.space tokens that queued items took under Block, whatever the
+// queue's current policy, or the parked goroutine hangs forever.
+func TestCommandQueueSetPolicyUnblocksBlockedPush(t *testing.T) {
+	q := NewCommandQueue(Block)
+
+	for i := 0; i < writeBufferSize; i++ {
+		q.Push(Command{Position: i})
+	}
+
+	pushed := make(chan struct{})
+	go func() {
+		q.Push(Command{Position: 99})
+		close(pushed)
+	}()
+
+	// Give the goroutine above time to park on q.space.
+	time.Sleep(50 * time.Millisecond)
+
+	q.SetPolicy(DropOldest)
+
+	stopchan := make(chan bool)
+	for i := 0; i < writeBufferSize; i++ {
+		if _, ok := q.Pop(stopchan); !ok {
+			t.Fatal("Pop() = false, want true")
+		}
+	}
+
+	select {
+	case <-pushed:
+	case <-time.After(time.Second):
+		t.Fatal("Push stayed blocked on q.space after SetPolicy moved away from Block")
+	}
+}
@@ -0,0 +1,49 @@
+package golaunch
+
+import "time"
+
+// TouchKind identifies the kind of touch event reported by the Launch's
+// touch strip.
+type TouchKind int
+
+const (
+	// Tap is a short touch.
+	Tap TouchKind = iota
+	// Hold is a sustained touch.
+	Hold
+	// Release is the end of a touch.
+	Release
+	// Slider is a touch that moved along the strip; Value carries its
+	// position.
+	Slider
+)
+
+// TouchEvent is a single notification from the Launch's touch strip.
+type TouchEvent struct {
+	Kind  TouchKind
+	Value int
+	At    time.Time
+}
+
+// decodeTouchEvent decodes a notification payload from touchCharID into a
+// TouchEvent. The kind is the first byte, the value (e.g. slider position)
+// the second, both best guesses absent official documentation.
+func decodeTouchEvent(b []byte) TouchEvent {
+	e := TouchEvent{At: time.Now()}
+	if len(b) > 0 {
+		switch b[0] {
+		case 0x01:
+			e.Kind = Tap
+		case 0x02:
+			e.Kind = Hold
+		case 0x03:
+			e.Kind = Release
+		case 0x04:
+			e.Kind = Slider
+		}
+	}
+	if len(b) > 1 {
+		e.Value = int(b[1])
+	}
+	return e
+}
@@ -0,0 +1,27 @@
+package golaunch
+
+import "testing"
+
+func TestDecodeTouchEvent(t *testing.T) {
+	tests := []struct {
+		name string
+		b    []byte
+		want TouchEvent
+	}{
+		{"tap", []byte{0x01}, TouchEvent{Kind: Tap}},
+		{"hold", []byte{0x02}, TouchEvent{Kind: Hold}},
+		{"release", []byte{0x03}, TouchEvent{Kind: Release}},
+		{"slider", []byte{0x04, 42}, TouchEvent{Kind: Slider, Value: 42}},
+		{"empty", nil, TouchEvent{}},
+		{"unknown kind", []byte{0xff}, TouchEvent{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := decodeTouchEvent(tt.b)
+			got.At = tt.want.At // At is always time.Now(); only compare Kind/Value.
+			if got != tt.want {
+				t.Fatalf("decodeTouchEvent(%v) = %+v, want %+v", tt.b, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package golaunch
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Command is a single Move, as sent to the wire by a Transport.
+type Command struct {
+	Position int
+	Speed    int
+}
+
+// Transport sits between a Launch implementation and the wire (BLE
+// characteristic writes, Buttplug device commands). Swapping it out gives
+// the project a hermetic integration-test surface, and lets real sessions
+// be captured for regression-debugging.
+type Transport interface {
+	// Send delivers a single command.
+	Send(c Command) error
+}
+
+// RecordingTransport writes every Command, with a monotonic timestamp, to
+// w. If next is non-nil, it also forwards the command there, so a session
+// can be recorded while still driving a real device.
+type RecordingTransport struct {
+	next  Transport
+	w     io.Writer
+	start time.Time
+}
+
+// NewRecordingTransport creates a RecordingTransport that writes to w and
+// forwards to next, if given.
+func NewRecordingTransport(w io.Writer, next Transport) *RecordingTransport {
+	return &RecordingTransport{next: next, w: w, start: time.Now()}
+}
+
+// Send writes c to the underlying writer and forwards it to next.
+func (t *RecordingTransport) Send(c Command) error {
+	if _, err := fmt.Fprintf(t.w, "%d %d %d\n",
+		time.Since(t.start)/time.Millisecond, c.Position, c.Speed); err != nil {
+		return err
+	}
+	if t.next != nil {
+		return t.next.Send(c)
+	}
+	return nil
+}
+
+// ReplayTransport reads Commands recorded by a RecordingTransport and
+// asserts that Send is called with the same commands, in the same order.
+type ReplayTransport struct {
+	commands []Command
+	idx      int
+}
+
+// NewReplayTransport parses a file written by a RecordingTransport.
+func NewReplayTransport(r io.Reader) (*ReplayTransport, error) {
+	t := &ReplayTransport{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		var ms int64
+		var c Command
+		if _, err := fmt.Sscanf(scanner.Text(), "%d %d %d", &ms, &c.Position, &c.Speed); err != nil {
+			return nil, err
+		}
+		t.commands = append(t.commands, c)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Send asserts that c matches the next recorded command.
+func (t *ReplayTransport) Send(c Command) error {
+	if t.idx >= len(t.commands) {
+		return errors.New("replay: no more recorded commands")
+	}
+	want := t.commands[t.idx]
+	t.idx++
+	if want != c {
+		return fmt.Errorf("replay: command %d: got %+v, want %+v", t.idx, c, want)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package golaunch
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestReplayTransportMismatch(t *testing.T) {
+	rt := &ReplayTransport{commands: []Command{{Position: 10, Speed: 30}}}
+
+	if err := rt.Send(Command{Position: 99, Speed: 30}); err == nil {
+		t.Fatal("Send with mismatched command: got nil error, want non-nil")
+	}
+}
+
+func TestReplayTransportMatch(t *testing.T) {
+	rt := &ReplayTransport{commands: []Command{
+		{Position: 10, Speed: 30},
+		{Position: 50, Speed: 60},
+	}}
+
+	if err := rt.Send(Command{Position: 10, Speed: 30}); err != nil {
+		t.Fatalf("Send with matching command: got %v, want nil", err)
+	}
+	if err := rt.Send(Command{Position: 50, Speed: 60}); err != nil {
+		t.Fatalf("Send with matching command: got %v, want nil", err)
+	}
+	if err := rt.Send(Command{Position: 0, Speed: 0}); err == nil {
+		t.Fatal("Send past the end of the recording: got nil error, want non-nil")
+	}
+}
+
+// TestLaunchTransportErrOnMismatch exercises the wiring from
+// writeFromBuffer, making sure a ReplayTransport mismatch, detected on the
+// background write goroutine, is observable through TransportErr.
+func TestLaunchTransportErrOnMismatch(t *testing.T) {
+	rt := &ReplayTransport{commands: []Command{{Position: 10, Speed: 30}}}
+	l, _ := NewLaunchWithTransport(rt).(*launch)
+	if err := l.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+
+	l.Move(99, 30)
+
+	deadline := time.After(time.Second)
+	for {
+		if err := l.TransportErr(); err != nil {
+			return
+		}
+		select {
+		case <-deadline:
+			t.Fatal("TransportErr stayed nil after a mismatched command")
+		case <-time.After(time.Millisecond):
+		}
+	}
+}